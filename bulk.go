@@ -0,0 +1,127 @@
+package libmongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BulkWriteError - ошибка отдельной операции внутри bulk write
+type BulkWriteError struct {
+	Index   int
+	Code    int
+	Message string
+}
+
+// BulkResult - сводный результат bulk write с ошибками по индексам операций
+type BulkResult struct {
+	InsertedCount int64
+	MatchedCount  int64
+	ModifiedCount int64
+	UpsertedCount int64
+	DeletedCount  int64
+	UpsertedIDs   map[int64]interface{}
+	WriteErrors   []BulkWriteError
+}
+
+// Bulk - построитель bulk write операции над коллекцией
+type Bulk struct {
+	coll    *mongo.Collection
+	models  []mongo.WriteModel
+	ordered bool
+	err     error
+}
+
+// Bulk - создание построителя bulk write для коллекции coll
+func (db *MongoDb) Bulk(coll string) *Bulk {
+	collection, err := db.getClient().Database(db.database).Collection(coll).CloneCollection()
+	return &Bulk{
+		coll:    collection,
+		ordered: true,
+		err:     err,
+	}
+}
+
+// SetOrdered - включение/отключение упорядоченного выполнения операций
+func (bk *Bulk) SetOrdered(ordered bool) *Bulk {
+	bk.ordered = ordered
+	return bk
+}
+
+// InsertOne - добавление операции вставки документа
+func (bk *Bulk) InsertOne(doc interface{}) *Bulk {
+	bk.models = append(bk.models, mongo.NewInsertOneModel().SetDocument(doc))
+	return bk
+}
+
+// UpdateOne - добавление операции обновления первого подходящего документа
+func (bk *Bulk) UpdateOne(filter, update interface{}) *Bulk {
+	bk.models = append(bk.models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update))
+	return bk
+}
+
+// UpdateMany - добавление операции обновления всех подходящих документов
+func (bk *Bulk) UpdateMany(filter, update interface{}) *Bulk {
+	bk.models = append(bk.models, mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(update))
+	return bk
+}
+
+// ReplaceOne - добавление операции полной замены документа
+func (bk *Bulk) ReplaceOne(filter, replacement interface{}) *Bulk {
+	bk.models = append(bk.models, mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(replacement))
+	return bk
+}
+
+// Upsert - добавление операции обновления с вставкой при отсутствии документа
+func (bk *Bulk) Upsert(filter, update interface{}) *Bulk {
+	bk.models = append(bk.models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	return bk
+}
+
+// DeleteOne - добавление операции удаления первого подходящего документа
+func (bk *Bulk) DeleteOne(filter interface{}) *Bulk {
+	bk.models = append(bk.models, mongo.NewDeleteOneModel().SetFilter(filter))
+	return bk
+}
+
+// DeleteMany - добавление операции удаления всех подходящих документов
+func (bk *Bulk) DeleteMany(filter interface{}) *Bulk {
+	bk.models = append(bk.models, mongo.NewDeleteManyModel().SetFilter(filter))
+	return bk
+}
+
+// Run - выполнение накопленных операций одним запросом к серверу
+func (bk *Bulk) Run(ctx context.Context) (BulkResult, error) {
+	if bk.err != nil {
+		return BulkResult{}, bk.err
+	}
+
+	opts := options.BulkWrite().SetOrdered(bk.ordered)
+	res, err := bk.coll.BulkWrite(ctx, bk.models, opts)
+
+	var result BulkResult
+	if res != nil {
+		result.InsertedCount = res.InsertedCount
+		result.MatchedCount = res.MatchedCount
+		result.ModifiedCount = res.ModifiedCount
+		result.DeletedCount = res.DeletedCount
+		result.UpsertedCount = res.UpsertedCount
+		result.UpsertedIDs = res.UpsertedIDs
+	}
+
+	var bwe mongo.BulkWriteException
+	if errors.As(err, &bwe) {
+		for _, we := range bwe.WriteErrors {
+			result.WriteErrors = append(result.WriteErrors, BulkWriteError{
+				Index:   we.Index,
+				Code:    we.Code,
+				Message: we.Message,
+			})
+		}
+		return result, nil
+	}
+
+	return result, err
+}
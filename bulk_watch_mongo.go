@@ -0,0 +1,106 @@
+package libmongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WriteModel - одна операция внутри bulk write
+type WriteModel = mongo.WriteModel
+
+// BulkWrite - групповое выполнение набора операций записи одним запросом
+// к серверу, с возвратом сводных счётчиков и ошибок по индексу операции
+func (m Mongo) BulkWrite(ctx context.Context, collection string, ops []WriteModel, ordered bool) (BulkResult, error) {
+	if !m.checkConnection(ctx) {
+		return BulkResult{}, ErrClientDisconnect
+	}
+
+	opts := options.BulkWrite().SetOrdered(ordered)
+	res, err := m.getCollection(collection).BulkWrite(ctx, ops, opts)
+
+	var result BulkResult
+	if res != nil {
+		result.InsertedCount = res.InsertedCount
+		result.MatchedCount = res.MatchedCount
+		result.ModifiedCount = res.ModifiedCount
+		result.DeletedCount = res.DeletedCount
+		result.UpsertedCount = res.UpsertedCount
+		result.UpsertedIDs = res.UpsertedIDs
+	}
+
+	var bwe mongo.BulkWriteException
+	if errors.As(err, &bwe) {
+		for _, we := range bwe.WriteErrors {
+			result.WriteErrors = append(result.WriteErrors, BulkWriteError{
+				Index:   we.Index,
+				Code:    we.Code,
+				Message: we.Message,
+			})
+		}
+		return result, nil
+	}
+
+	return result, errors.WithStack(err)
+}
+
+// WatchOpts - параметры подписки на change stream
+type WatchOpts struct {
+	ResumeToken          bson.Raw
+	StartAtOperationTime *primitive.Timestamp
+	FullDocument         options.FullDocument
+}
+
+// Watch - подписка на события коллекции с декодированием в ChangeEvent
+// и автоматическим переподключением по сохранённому resume token
+func (m Mongo) Watch(ctx context.Context, collection string, pipeline Pipeline, handler ChangeHandler, opts ...WatchOpts) error {
+	if !m.checkConnection(ctx) {
+		return ErrClientDisconnect
+	}
+
+	var o WatchOpts
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	resumeToken := o.ResumeToken
+	startAt := o.StartAtOperationTime
+
+	for {
+		streamOpts := options.ChangeStream()
+		if o.FullDocument != "" {
+			streamOpts.SetFullDocument(o.FullDocument)
+		}
+		if resumeToken != nil {
+			streamOpts.SetResumeAfter(resumeToken)
+		} else if startAt != nil {
+			streamOpts.SetStartAtOperationTime(startAt)
+		}
+
+		stream, err := m.getCollection(collection).Watch(ctx, pipeline, streamOpts)
+		if err != nil {
+			if isTransientWatchErr(err) {
+				time.Sleep(watchReconnectDelay)
+				continue
+			}
+			return errors.WithStack(err)
+		}
+
+		runErr := runChangeStream(ctx, stream, handler, &resumeToken)
+		if runErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return errors.WithStack(runErr)
+		}
+		if !isTransientWatchErr(runErr) {
+			return errors.WithStack(runErr)
+		}
+		time.Sleep(watchReconnectDelay)
+	}
+}
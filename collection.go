@@ -0,0 +1,98 @@
+package libmongo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Collection - типизированная обёртка над коллекцией, декодирующая документы
+// напрямую в T без ручных замыканий DecodeDocFunc
+type Collection[T any] struct {
+	coll *mongo.Collection
+}
+
+// NewCollection - получение типизированной обёртки над коллекцией
+func NewCollection[T any](m Mongo, collection string) Collection[T] {
+	return Collection[T]{coll: m.getCollection(collection)}
+}
+
+// FindOne - поиск документа с декодированием в T, ErrNotFound если не найден
+func (c Collection[T]) FindOne(ctx context.Context, filter interface{}) (T, error) {
+	var v T
+	err := c.coll.FindOne(ctx, filter).Decode(&v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return v, ErrNotFound
+	}
+	return v, errors.WithStack(err)
+}
+
+// FindOneOpt - поиск документа, возвращает nil вместо ошибки, если документ не найден
+func (c Collection[T]) FindOneOpt(ctx context.Context, filter interface{}) (*T, error) {
+	var v T
+	err := c.coll.FindOne(ctx, filter).Decode(&v)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &v, nil
+}
+
+// FindAll - поиск всех документов, подходящих под filter
+func (c Collection[T]) FindAll(ctx context.Context, filter interface{}) ([]T, error) {
+	cursor, err := c.coll.Find(ctx, filter)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []T
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+// InsertOne - вставка документа
+func (c Collection[T]) InsertOne(ctx context.Context, doc T) error {
+	_, err := c.coll.InsertOne(ctx, doc)
+	return errors.WithStack(err)
+}
+
+// UpdateByID - обновление документа по _id. Поле _id самого doc исключается
+// из $set, иначе сервер отвергает попытку изменить неизменяемый _id, если
+// в doc он не совпадает с id (например, зануляется для нового документа)
+func (c Collection[T]) UpdateByID(ctx context.Context, id interface{}, doc T) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var set bson.M
+	if err := bson.Unmarshal(raw, &set); err != nil {
+		return errors.WithStack(err)
+	}
+	delete(set, "_id")
+
+	_, err = c.coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, bson.D{{Key: "$set", Value: set}})
+	return errors.WithStack(err)
+}
+
+// AggregateAll - аггрегация с декодированием результата в []T
+func (c Collection[T]) AggregateAll(ctx context.Context, pipeline Pipeline) ([]T, error) {
+	cursor, err := c.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []T
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
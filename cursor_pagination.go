@@ -0,0 +1,131 @@
+package libmongo
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultPageSize = 20
+
+// SortKey - одно поле компаундной сортировки с направлением
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// FindPage - keyset-пагинация: следующая страница запрашивается по значениям
+// полей сортировки и _id последнего документа предыдущей страницы, без Skip,
+// что остаётся дешёвым и устойчивым к вставкам на больших коллекциях
+func (c Collection[T]) FindPage(ctx context.Context, filter interface{}, sort []SortKey, pageSize int64, token string) ([]T, string, error) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	query := filter
+	if token != "" {
+		cursorFilter, err := decodeCursor(token, sort)
+		if err != nil {
+			return nil, "", err
+		}
+		query = bson.M{"$and": []interface{}{filter, cursorFilter}}
+	}
+
+	sortDoc := bson.D{}
+	for _, s := range sort {
+		dir := 1
+		if s.Desc {
+			dir = -1
+		}
+		sortDoc = append(sortDoc, bson.E{Key: s.Field, Value: dir})
+	}
+	sortDoc = append(sortDoc, bson.E{Key: "_id", Value: 1})
+
+	findOpts := options.Find().SetSort(sortDoc).SetLimit(pageSize)
+
+	cursor, err := c.coll.Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+	defer cursor.Close(ctx)
+
+	var result []T
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+
+	if int64(len(result)) < pageSize {
+		return result, "", nil
+	}
+
+	nextToken, err := encodeCursor(result[len(result)-1], sort)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, nextToken, nil
+}
+
+func encodeCursor(doc interface{}, sort []SortKey) (string, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+
+	var m bson.M
+	if err := bson.Unmarshal(raw, &m); err != nil {
+		return "", err
+	}
+
+	values := bson.M{"_id": m["_id"]}
+	for _, s := range sort {
+		values[s.Field] = m[s.Field]
+	}
+
+	b, err := bson.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(token string, sort []SortKey) (bson.M, error) {
+	raw, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var values bson.M
+	if err := bson.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	var or []bson.M
+	prefix := bson.M{}
+	for _, s := range sort {
+		op := "$gt"
+		if s.Desc {
+			op = "$lt"
+		}
+
+		clause := bson.M{}
+		for k, v := range prefix {
+			clause[k] = v
+		}
+		clause[s.Field] = bson.M{op: values[s.Field]}
+		or = append(or, clause)
+
+		prefix[s.Field] = values[s.Field]
+	}
+
+	idClause := bson.M{}
+	for k, v := range prefix {
+		idClause[k] = v
+	}
+	idClause["_id"] = bson.M{"$gt": values["_id"]}
+	or = append(or, idClause)
+
+	return bson.M{"$or": or}, nil
+}
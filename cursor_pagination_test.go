@@ -0,0 +1,41 @@
+package libmongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	sort := []SortKey{{Field: "name"}, {Field: "age", Desc: true}}
+
+	type doc struct {
+		ID   string `bson:"_id"`
+		Name string `bson:"name"`
+		Age  int    `bson:"age"`
+	}
+
+	token, err := encodeCursor(doc{ID: "1", Name: "alice", Age: 30}, sort)
+	if err != nil {
+		t.Fatalf("encodeCursor failed: %v", err)
+	}
+
+	filter, err := decodeCursor(token, sort)
+	if err != nil {
+		t.Fatalf("decodeCursor failed: %v", err)
+	}
+
+	if len(filter) != 1 {
+		t.Fatalf("expected a single $or clause, got %+v", filter)
+	}
+
+	or, ok := filter["$or"].([]bson.M)
+	if !ok {
+		t.Fatalf("$or is not a []bson.M: %T", filter["$or"])
+	}
+
+	// one clause per sort key plus the trailing _id tiebreaker
+	if len(or) != len(sort)+1 {
+		t.Fatalf("expected %d clauses, got %d", len(sort)+1, len(or))
+	}
+}
@@ -0,0 +1,167 @@
+package libmongo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FileInfo - метаданные файла, хранящегося в GridFS
+type FileInfo struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Name       string             `bson:"filename"`
+	Size       int64              `bson:"length"`
+	ChunkSize  int32              `bson:"chunkSize"`
+	UploadDate time.Time          `bson:"uploadDate"`
+	Metadata   bson.M             `bson:"metadata"`
+}
+
+const defaultGridFSChunkSizeBytes int32 = 255 * 1024
+
+// nativeDatabase - получение нативного *mongo.Database. qmgo.Database не
+// экспортирует обёрнутый объект напрямую, поэтому он извлекается через
+// CloneCollection, которая единственная отдаёт нативный драйвер наружу
+func (db *MongoDb) nativeDatabase() (*mongo.Database, error) {
+	collection, err := db.getClient().Database(db.database).Collection("fs.files").CloneCollection()
+	if err != nil {
+		return nil, err
+	}
+	return collection.Database(), nil
+}
+
+func (db *MongoDb) bucket(name string, chunkSizeBytes int32) (*gridfs.Bucket, error) {
+	opts := options.GridFSBucket()
+	if name != "" {
+		opts.SetName(name)
+	}
+	if chunkSizeBytes > 0 {
+		opts.SetChunkSizeBytes(chunkSizeBytes)
+	}
+
+	database, err := db.nativeDatabase()
+	if err != nil {
+		return nil, err
+	}
+	return gridfs.NewBucket(database, opts)
+}
+
+// PutFile - загрузка файла в GridFS, возвращает его ObjectID. ctx не
+// используется: UploadFromStreamWithID в этой версии драйвера не принимает
+// контекст, поэтому загрузка не ограничивается ни ctx, ни дедлайном
+func (db *MongoDb) PutFile(ctx context.Context, bucketName, name string, r io.Reader, metadata bson.M, chunkSizeBytes ...int32) (primitive.ObjectID, error) {
+	if !db.IsConnected() {
+		return primitive.NilObjectID, fmt.Errorf("%s", errorNotConnected)
+	}
+
+	var chunkSize int32
+	if len(chunkSizeBytes) > 0 {
+		chunkSize = chunkSizeBytes[0]
+	}
+
+	b, err := db.bucket(bucketName, chunkSize)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	uploadOpts := options.GridFSUpload()
+	if metadata != nil {
+		uploadOpts.SetMetadata(metadata)
+	}
+
+	objID := primitive.NewObjectID()
+	if err := b.UploadFromStreamWithID(objID, name, r, uploadOpts); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return objID, nil
+}
+
+// GetFile - получение потока чтения файла и его метаданных по ID. ctx не
+// используется: OpenDownloadStream в этой версии драйвера не принимает
+// контекст, таймаут чтения задаётся через SetReadDeadline у возвращённого
+// *gridfs.DownloadStream при необходимости
+func (db *MongoDb) GetFile(ctx context.Context, bucketName string, id primitive.ObjectID) (io.ReadCloser, FileInfo, error) {
+	if !db.IsConnected() {
+		return nil, FileInfo{}, fmt.Errorf("%s", errorNotConnected)
+	}
+
+	b, err := db.bucket(bucketName, 0)
+	if err != nil {
+		return nil, FileInfo{}, err
+	}
+
+	stream, err := b.OpenDownloadStream(id)
+	if err != nil {
+		return nil, FileInfo{}, err
+	}
+
+	file := stream.GetFile()
+	info := FileInfo{
+		ID:         id,
+		Name:       file.Name,
+		Size:       file.Length,
+		ChunkSize:  file.ChunkSize,
+		UploadDate: file.UploadDate,
+	}
+	if file.Metadata != nil {
+		_ = bson.Unmarshal(file.Metadata, &info.Metadata)
+	}
+
+	return stream, info, nil
+}
+
+// DeleteFile - удаление файла из GridFS по ID
+func (db *MongoDb) DeleteFile(ctx context.Context, bucketName string, id primitive.ObjectID) error {
+	if !db.IsConnected() {
+		return fmt.Errorf("%s", errorNotConnected)
+	}
+
+	b, err := db.bucket(bucketName, 0)
+	if err != nil {
+		return err
+	}
+
+	return b.DeleteContext(ctx, id)
+}
+
+// ListFiles - список файлов бакета, отфильтрованных и отсортированных
+func (db *MongoDb) ListFiles(ctx context.Context, bucketName string, filter interface{}, sort string, limit int64) ([]FileInfo, error) {
+	if !db.IsConnected() {
+		return nil, fmt.Errorf("%s", errorNotConnected)
+	}
+
+	if filter == nil {
+		filter = M{}
+	}
+
+	findOpts := options.GridFSFind()
+	if sort != "" {
+		findOpts.SetSort(bson.D{{Key: sort, Value: 1}})
+	}
+	if limit > 0 {
+		findOpts.SetLimit(int32(limit))
+	}
+
+	b, err := db.bucket(bucketName, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := b.FindContext(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []FileInfo
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
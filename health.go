@@ -0,0 +1,204 @@
+package libmongo
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/qiniu/qmgo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	defaultHealthCheckInterval  = 10 * time.Second
+	defaultReconnectBackoffBase = 500 * time.Millisecond
+	defaultReconnectBackoffMax  = 30 * time.Second
+)
+
+// HealthStatus - снимок состояния соединения с MongoDB на момент последней проверки
+type HealthStatus struct {
+	Connected   bool
+	LastPing    time.Time
+	Latency     time.Duration
+	PrimaryHost string
+	ReplicaSet  string
+}
+
+// Health - снимок состояния соединения по данным фонового health-check
+func (db *MongoDb) Health() HealthStatus {
+	db.RWMutex.RLock()
+	defer db.RWMutex.RUnlock()
+	return db.health
+}
+
+// SetHealthCheckInterval - установка периода фоновой проверки соединения
+func (db *MongoDb) SetHealthCheckInterval(d time.Duration) {
+	db.RWMutex.Lock()
+	db.healthCheckInterval = d
+	db.RWMutex.Unlock()
+}
+
+// SetReconnectBackoff - установка параметров экспоненциальной задержки
+// между попытками переподключения
+func (db *MongoDb) SetReconnectBackoff(base, max time.Duration) {
+	db.RWMutex.Lock()
+	db.reconnectBackoffBase = base
+	db.reconnectBackoffMax = max
+	db.RWMutex.Unlock()
+}
+
+// OnStateChange - регистрация колбэка, вызываемого при смене состояния
+// соединения (connected/disconnected)
+func (db *MongoDb) OnStateChange(cb func(HealthStatus)) {
+	db.RWMutex.Lock()
+	db.onStateChange = cb
+	db.RWMutex.Unlock()
+}
+
+func (db *MongoDb) startHealthCheck(uri string) {
+	db.RWMutex.Lock()
+	db.uri = uri
+	db.stopCh = make(chan struct{})
+	db.RWMutex.Unlock()
+
+	db.setHealth(db.ping())
+
+	go db.healthCheckLoop()
+}
+
+func (db *MongoDb) stopHealthCheck() {
+	db.RWMutex.Lock()
+	stopCh := db.stopCh
+	db.stopCh = nil
+	db.RWMutex.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+	}
+}
+
+func (db *MongoDb) healthCheckLoop() {
+	for {
+		db.RWMutex.RLock()
+		interval := db.healthCheckInterval
+		stopCh := db.stopCh
+		db.RWMutex.RUnlock()
+
+		if interval <= 0 {
+			interval = defaultHealthCheckInterval
+		}
+		if stopCh == nil {
+			return
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(interval):
+		}
+
+		status := db.ping()
+		db.setHealth(status)
+
+		if !status.Connected {
+			db.reconnect(stopCh)
+		}
+	}
+}
+
+func (db *MongoDb) ping() HealthStatus {
+	status := HealthStatus{LastPing: time.Now()}
+
+	client := db.getClient()
+	if client == nil {
+		return status
+	}
+
+	start := time.Now()
+	if err := client.Ping(int64(mongoConnectionTimeout / time.Millisecond)); err != nil {
+		return status
+	}
+	status.Latency = time.Since(start)
+	status.Connected = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), mongoConnectionTimeout)
+	defer cancel()
+
+	var hello bson.M
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err == nil {
+		if primary, ok := hello["primary"].(string); ok {
+			status.PrimaryHost = primary
+		}
+		if set, ok := hello["setName"].(string); ok {
+			status.ReplicaSet = set
+		}
+	}
+
+	return status
+}
+
+func (db *MongoDb) setHealth(status HealthStatus) {
+	db.RWMutex.Lock()
+	prev := db.health
+	db.health = status
+	cb := db.onStateChange
+	db.RWMutex.Unlock()
+
+	if cb != nil && prev.Connected != status.Connected {
+		cb(status)
+	}
+}
+
+func (db *MongoDb) reconnect(stopCh chan struct{}) {
+	db.RWMutex.RLock()
+	uri := db.uri
+	backoff := db.reconnectBackoffBase
+	backoffMax := db.reconnectBackoffMax
+	db.RWMutex.RUnlock()
+
+	if backoff <= 0 {
+		backoff = defaultReconnectBackoffBase
+	}
+	if backoffMax <= 0 {
+		backoffMax = defaultReconnectBackoffMax
+	}
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(jitter(backoff)):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), mongoConnectionTimeout)
+		client, err := qmgo.NewClient(ctx, &qmgo.Config{Uri: uri})
+		cancel()
+		if err == nil {
+			db.RWMutex.Lock()
+			old := db.client
+			db.client = client
+			db.RWMutex.Unlock()
+
+			if old != nil {
+				// best-effort: the pool is being replaced, a failure to close
+				// the stale one must not block the new connection taking over
+				_ = old.Close(context.Background())
+			}
+
+			db.setHealth(db.ping())
+			return
+		}
+
+		backoff *= 2
+		if backoff > backoffMax {
+			backoff = backoffMax
+		}
+	}
+}
+
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d)/2+1))
+}
@@ -0,0 +1,128 @@
+package libmongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// EnsureIndexes - создание набора индексов на коллекции
+func (db *MongoDb) EnsureIndexes(ctx context.Context, coll string, models []IndexModel) error {
+	if !db.IsConnected() {
+		return fmt.Errorf("%s", errorNotConnected)
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	collection, err := db.getClient().Database(db.database).Collection(coll).CloneCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.Indexes().CreateMany(ctx, models)
+	return err
+}
+
+// DropIndex - удаление индекса коллекции по имени
+func (db *MongoDb) DropIndex(ctx context.Context, coll, name string) error {
+	if !db.IsConnected() {
+		return fmt.Errorf("%s", errorNotConnected)
+	}
+
+	collection, err := db.getClient().Database(db.database).Collection(coll).CloneCollection()
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.Indexes().DropOne(ctx, name)
+	return err
+}
+
+// ListIndexes - список индексов коллекции как они заведены на сервере
+func (db *MongoDb) ListIndexes(ctx context.Context, coll string) ([]bson.M, error) {
+	if !db.IsConnected() {
+		return nil, fmt.Errorf("%s", errorNotConnected)
+	}
+
+	collection, err := db.getClient().Database(db.database).Collection(coll).CloneCollection()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []bson.M
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// SyncIndexes - приведение индексов коллекций к декларативной спецификации:
+// недостающие индексы создаются, лишние (отсутствующие в spec) удаляются
+func (db *MongoDb) SyncIndexes(ctx context.Context, spec map[string][]IndexModel) error {
+	for coll, models := range spec {
+		existing, err := db.ListIndexes(ctx, coll)
+		if err != nil {
+			return err
+		}
+
+		existingNames := make(map[string]bool, len(existing))
+		for _, idx := range existing {
+			if name, ok := idx["name"].(string); ok {
+				existingNames[name] = true
+			}
+		}
+
+		desiredNames := make(map[string]bool, len(models))
+		var toCreate []IndexModel
+		for _, m := range models {
+			name := indexName(m)
+			desiredNames[name] = true
+			if !existingNames[name] {
+				toCreate = append(toCreate, m)
+			}
+		}
+
+		if err := db.EnsureIndexes(ctx, coll, toCreate); err != nil {
+			return err
+		}
+
+		for name := range existingNames {
+			if name == "_id_" || desiredNames[name] {
+				continue
+			}
+			if err := db.DropIndex(ctx, coll, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// indexName - имя индекса: явно заданное в опциях либо сгенерированное по ключам.
+// Имя генерируется только для ключей типа bson.D, для прочих типов Keys
+// возвращается пустая строка
+func indexName(m IndexModel) string {
+	if m.Options != nil && m.Options.Name != nil {
+		return *m.Options.Name
+	}
+
+	keys, ok := m.Keys.(bson.D)
+	if !ok {
+		return ""
+	}
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", k.Key, k.Value))
+	}
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,155 @@
+package libmongo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexSpec - декларативное описание индекса коллекции
+type IndexSpec struct {
+	Name                    string
+	Keys                    bson.D
+	Unique                  bool
+	Sparse                  bool
+	ExpireAfterSeconds      *int32
+	PartialFilterExpression bson.D
+	Collation               *options.Collation
+	Weights                 bson.D
+}
+
+func (s IndexSpec) toModel() mongo.IndexModel {
+	opts := options.Index()
+	if s.Unique {
+		opts.SetUnique(true)
+	}
+	if s.Sparse {
+		opts.SetSparse(true)
+	}
+	if s.ExpireAfterSeconds != nil {
+		opts.SetExpireAfterSeconds(*s.ExpireAfterSeconds)
+	}
+	if s.PartialFilterExpression != nil {
+		opts.SetPartialFilterExpression(s.PartialFilterExpression)
+	}
+	if s.Collation != nil {
+		opts.SetCollation(s.Collation)
+	}
+	if s.Weights != nil {
+		opts.SetWeights(s.Weights)
+	}
+	opts.SetName(s.indexName())
+
+	return mongo.IndexModel{Keys: s.Keys, Options: opts}
+}
+
+// indexName - явно заданное имя индекса либо стабильный хэш от ключей и
+// опций, используемый для сравнения "уже существует / нужно создать"
+func (s IndexSpec) indexName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+
+	b, _ := json.Marshal(struct {
+		Keys      bson.D
+		Unique    bool
+		Sparse    bool
+		TTL       *int32
+		Partial   bson.D
+		Collation *options.Collation
+		Weights   bson.D
+	}{s.Keys, s.Unique, s.Sparse, s.ExpireAfterSeconds, s.PartialFilterExpression, s.Collation, s.Weights})
+
+	sum := sha256.Sum256(b)
+	return "ix_" + hex.EncodeToString(sum[:8])
+}
+
+// EnsureIndexes - создание набора индексов на коллекции
+func (m Mongo) EnsureIndexes(ctx context.Context, coll string, specs []IndexSpec) error {
+	if !m.checkConnection(ctx) {
+		return ErrClientDisconnect
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.IndexModel, 0, len(specs))
+	for _, s := range specs {
+		models = append(models, s.toModel())
+	}
+
+	_, err := m.getCollection(coll).Indexes().CreateMany(ctx, models)
+	return errors.WithStack(err)
+}
+
+// DropAllIndexes - удаление всех пользовательских индексов коллекции
+func (m Mongo) DropAllIndexes(ctx context.Context, coll string) error {
+	if !m.checkConnection(ctx) {
+		return ErrClientDisconnect
+	}
+
+	_, err := m.getCollection(coll).Indexes().DropAll(ctx)
+	return errors.WithStack(err)
+}
+
+// SyncIndexes - приведение индексов коллекции к декларативному списку specs:
+// недостающие индексы создаются, совпадающие не трогаются, а при drop=true
+// индексы, отсутствующие в specs, удаляются
+func (m Mongo) SyncIndexes(ctx context.Context, coll string, specs []IndexSpec, drop bool) error {
+	if !m.checkConnection(ctx) {
+		return ErrClientDisconnect
+	}
+
+	cursor, err := m.getCollection(coll).Indexes().List(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer cursor.Close(ctx)
+
+	var existing []bson.M
+	if err := cursor.All(ctx, &existing); err != nil {
+		return errors.WithStack(err)
+	}
+
+	existingNames := make(map[string]bool, len(existing))
+	for _, idx := range existing {
+		if name, ok := idx["name"].(string); ok {
+			existingNames[name] = true
+		}
+	}
+
+	desiredNames := make(map[string]bool, len(specs))
+	var toCreate []IndexSpec
+	for _, s := range specs {
+		name := s.indexName()
+		desiredNames[name] = true
+		if !existingNames[name] {
+			toCreate = append(toCreate, s)
+		}
+	}
+
+	if err := m.EnsureIndexes(ctx, coll, toCreate); err != nil {
+		return err
+	}
+
+	if !drop {
+		return nil
+	}
+
+	for name := range existingNames {
+		if name == "_id_" || desiredNames[name] {
+			continue
+		}
+		if _, err := m.getCollection(coll).Indexes().DropOne(ctx, name); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package libmongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIndexSpecNameStable(t *testing.T) {
+	a := IndexSpec{Keys: bson.D{{Key: "email", Value: 1}}, Unique: true}
+	b := IndexSpec{Keys: bson.D{{Key: "email", Value: 1}}, Unique: true}
+
+	if a.indexName() != b.indexName() {
+		t.Fatalf("expected identical specs to hash to the same name: %q vs %q", a.indexName(), b.indexName())
+	}
+
+	c := IndexSpec{Keys: bson.D{{Key: "email", Value: 1}}, Unique: false}
+	if a.indexName() == c.indexName() {
+		t.Fatalf("expected differing specs to hash to different names")
+	}
+}
@@ -25,6 +25,14 @@ type MongoDb struct {
 	client    *qmgo.Client
 	database  string
 	maxTimeMS time.Duration
+
+	uri                  string
+	health               HealthStatus
+	healthCheckInterval  time.Duration
+	reconnectBackoffBase time.Duration
+	reconnectBackoffMax  time.Duration
+	onStateChange        func(HealthStatus)
+	stopCh               chan struct{}
 }
 
 type (
@@ -50,7 +58,12 @@ func NewConnection(uri, database string) (*MongoDb, error) {
 	defer cancel()
 
 	db.client, err = qmgo.NewClient(ctx, &qmgo.Config{Uri: uri})
-	return &db, err
+	if err != nil {
+		return &db, err
+	}
+
+	db.startHealthCheck(uri)
+	return &db, nil
 }
 
 func NewConnectionWithTimeout(uri, database string, timeout time.Duration) (*MongoDb, error) {
@@ -67,11 +80,28 @@ func NewConnectionWithTimeout(uri, database string, timeout time.Duration) (*Mon
 	tm := int64(timeout)
 	var err error
 	db.client, err = qmgo.NewClient(ctx, &qmgo.Config{Uri: uri, ConnectTimeoutMS: &tm})
-	return &db, err
+	if err != nil {
+		return &db, err
+	}
+
+	db.startHealthCheck(uri)
+	return &db, nil
 }
 
+// IsConnected - отражает фактическое состояние соединения по данным
+// последней проверки фонового health-check, а не просто наличие клиента
 func (db *MongoDb) IsConnected() bool {
-	return db.client != nil
+	db.RWMutex.RLock()
+	defer db.RWMutex.RUnlock()
+	return db.client != nil && db.health.Connected
+}
+
+// getClient - конкурентно-безопасное чтение текущего клиента: background
+// health-check может заменить db.client на переподключённый в любой момент
+func (db *MongoDb) getClient() *qmgo.Client {
+	db.RWMutex.RLock()
+	defer db.RWMutex.RUnlock()
+	return db.client
 }
 
 func (db *MongoDb) SetMaxTimeMS(d time.Duration) {
@@ -81,8 +111,10 @@ func (db *MongoDb) SetMaxTimeMS(d time.Duration) {
 }
 
 func (db *MongoDb) Disconnect() {
-	if db.IsConnected() {
-		if err := db.client.Close(context.Background()); err != nil {
+	db.stopHealthCheck()
+	client := db.getClient()
+	if client != nil {
+		if err := client.Close(context.Background()); err != nil {
 			panic(err)
 		}
 	}
@@ -96,7 +128,7 @@ func (db *MongoDb) Insert(coll string, v interface{}) error {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	_, err := db.client.Database(db.database).Collection(coll).InsertOne(ctx, v)
+	_, err := db.getClient().Database(db.database).Collection(coll).InsertOne(ctx, v)
 	return err
 }
 
@@ -108,7 +140,7 @@ func (db *MongoDb) InsertMany(coll string, v interface{}) error {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	_, err := db.client.Database(db.database).Collection(coll).InsertMany(ctx, v)
+	_, err := db.getClient().Database(db.database).Collection(coll).InsertMany(ctx, v)
 	return err
 }
 
@@ -120,7 +152,7 @@ func (db *MongoDb) InsertBulk(coll string, v ...interface{}) error {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	b := db.client.Database(db.database).Collection(coll).Bulk()
+	b := db.getClient().Database(db.database).Collection(coll).Bulk()
 	b.SetOrdered(false)
 	for _, vv := range v {
 		b.InsertOne(vv)
@@ -143,7 +175,7 @@ func (db *MongoDb) Find(coll string, query map[string]interface{}, v interface{}
 		bsonQuery[k] = qv
 	}
 
-	return db.client.Database(db.database).Collection(coll).Find(ctx, bsonQuery).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, bsonQuery).All(v)
 }
 
 func (db *MongoDb) Pipe(coll string, query []M, v interface{}) error {
@@ -154,7 +186,7 @@ func (db *MongoDb) Pipe(coll string, query []M, v interface{}) error {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).Aggregate(ctx, query).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Aggregate(ctx, query).All(v)
 }
 
 func (db *MongoDb) PipeOne(coll string, query []M, v interface{}) error {
@@ -165,7 +197,7 @@ func (db *MongoDb) PipeOne(coll string, query []M, v interface{}) error {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).Aggregate(ctx, query).One(v)
+	return db.getClient().Database(db.database).Collection(coll).Aggregate(ctx, query).One(v)
 }
 
 func (db *MongoDb) FindByID(coll string, id string, v interface{}) bool {
@@ -176,7 +208,7 @@ func (db *MongoDb) FindByID(coll string, id string, v interface{}) bool {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return qmgo.ErrNoSuchDocuments != db.client.Database(db.database).Collection(coll).Find(ctx, M{"_id:": id}).One(v)
+	return qmgo.ErrNoSuchDocuments != db.getClient().Database(db.database).Collection(coll).Find(ctx, M{"_id:": id}).One(v)
 }
 
 func (db *MongoDb) FindAll(coll string, v interface{}) error {
@@ -186,7 +218,7 @@ func (db *MongoDb) FindAll(coll string, v interface{}) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	return db.client.Database(db.database).Collection(coll).Find(ctx, M{}).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, M{}).All(v)
 }
 
 func (db *MongoDb) FindWithSelectAll(coll string, query, sel, output interface{}) error {
@@ -197,7 +229,7 @@ func (db *MongoDb) FindWithSelectAll(coll string, query, sel, output interface{}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).Select(sel).All(output)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Select(sel).All(output)
 }
 
 func (db *MongoDb) FindWithQuery(coll string, query interface{}, v interface{}) error {
@@ -208,7 +240,7 @@ func (db *MongoDb) FindWithQuery(coll string, query interface{}, v interface{})
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).One(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).One(v)
 }
 
 func (db *MongoDb) FindWithQuerySortOne(coll string, query interface{},
@@ -220,7 +252,7 @@ func (db *MongoDb) FindWithQuerySortOne(coll string, query interface{},
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).Sort(order).One(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Sort(order).One(v)
 }
 
 func (db *MongoDb) FindWithQuerySortAll(coll string, query interface{},
@@ -232,7 +264,7 @@ func (db *MongoDb) FindWithQuerySortAll(coll string, query interface{},
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).Sort(order).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Sort(order).All(v)
 }
 
 func (db *MongoDb) FindWithQuerySortLimitAll(coll string, query interface{},
@@ -244,7 +276,7 @@ func (db *MongoDb) FindWithQuerySortLimitAll(coll string, query interface{},
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).Sort(order).Limit(limit).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Sort(order).Limit(limit).All(v)
 }
 
 func (db *MongoDb) FindWithQueryOne(coll string, query interface{}, v interface{}) error {
@@ -254,7 +286,7 @@ func (db *MongoDb) FindWithQueryOne(coll string, query interface{}, v interface{
 
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).One(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).One(v)
 }
 
 func (db *MongoDb) FindWithQueryAll(coll string, query interface{}, v interface{}) error {
@@ -263,7 +295,7 @@ func (db *MongoDb) FindWithQueryAll(coll string, query interface{}, v interface{
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).All(v)
 }
 
 func (db *MongoDb) FindWithQuerySortLimitOffsetAll(coll string, query interface{}, sort string,
@@ -273,7 +305,7 @@ func (db *MongoDb) FindWithQuerySortLimitOffsetAll(coll string, query interface{
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).Sort(sort).Limit(limit).Skip(offset).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Sort(sort).Limit(limit).Skip(offset).All(v)
 }
 
 func (db *MongoDb) FindWithQuerySortLimitOffsetTotalAll(coll string, query interface{},
@@ -285,10 +317,10 @@ func (db *MongoDb) FindWithQuerySortLimitOffsetTotalAll(coll string, query inter
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS*2)
 	defer cancel()
 	if total != nil {
-		*total, _ = db.client.Database(db.database).Collection(coll).Find(ctx, query).Count()
+		*total, _ = db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Count()
 	}
 
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).Sort(sort).Limit(limit).Skip(offset).All(v)
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Sort(sort).Limit(limit).Skip(offset).All(v)
 }
 
 func (db *MongoDb) Count(coll string, query interface{}) (int64, error) {
@@ -297,7 +329,7 @@ func (db *MongoDb) Count(coll string, query interface{}) (int64, error) {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	return db.client.Database(db.database).Collection(coll).Find(ctx, query).Count()
+	return db.getClient().Database(db.database).Collection(coll).Find(ctx, query).Count()
 }
 
 func (db *MongoDb) Update(coll string, id interface{}, v interface{}) error {
@@ -307,7 +339,7 @@ func (db *MongoDb) Update(coll string, id interface{}, v interface{}) error {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	return db.client.Database(db.database).Collection(coll).UpdateOne(ctx, M{"_id": id}, M{"$set": v})
+	return db.getClient().Database(db.database).Collection(coll).UpdateOne(ctx, M{"_id": id}, M{"$set": v})
 }
 
 func (db *MongoDb) UpdateWithQuery(coll string, query interface{}, set interface{}) error {
@@ -323,7 +355,7 @@ func (db *MongoDb) UpdateWithQuery(coll string, query interface{}, set interface
 	// defer sess.Close()
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	_, err := db.client.Database(db.database).Collection(coll).UpdateAll(ctx, query, set)
+	_, err := db.getClient().Database(db.database).Collection(coll).UpdateAll(ctx, query, set)
 	return err
 }
 
@@ -342,7 +374,7 @@ func (db *MongoDb) UpdateWithQueryAll(coll string, query interface{}, set interf
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
 
-	_, err := db.client.Database(db.database).Collection(coll).UpdateAll(ctx, query, set)
+	_, err := db.getClient().Database(db.database).Collection(coll).UpdateAll(ctx, query, set)
 
 	return err
 }
@@ -353,7 +385,7 @@ func (db *MongoDb) Upsert(coll string, id interface{}, v interface{}) (*UpdateRe
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	return db.client.Database(db.database).Collection(coll).Upsert(ctx, M{"_id": id}, v)
+	return db.getClient().Database(db.database).Collection(coll).Upsert(ctx, M{"_id": id}, v)
 }
 
 func (db *MongoDb) UpsertWithQuery(coll string, query interface{}, set interface{}) error {
@@ -362,7 +394,7 @@ func (db *MongoDb) UpsertWithQuery(coll string, query interface{}, set interface
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	var _, err = db.client.Database(db.database).Collection(coll).Upsert(ctx, query, set)
+	var _, err = db.getClient().Database(db.database).Collection(coll).Upsert(ctx, query, set)
 
 	return err
 }
@@ -383,7 +415,7 @@ func (db *MongoDb) UpsertMulti(coll string, id []interface{}, v []interface{}) e
 	defer cancel()
 	for index < len(id) {
 		// TODO: fix errcheck linter issue: return value is not checked
-		db.client.Database(db.database).Collection(coll).Upsert(ctx, M{"_id": id[index]}, v[index])
+		db.getClient().Database(db.database).Collection(coll).Upsert(ctx, M{"_id": id[index]}, v[index])
 		index++
 	}
 
@@ -395,7 +427,7 @@ func (db *MongoDb) Remove(coll string, id interface{}) error {
 		return fmt.Errorf("%s", errorNotConnected)
 	}
 
-	_, err := db.client.Database(db.database).Collection(coll).RemoveAll(context.Background(), M{"_id": id})
+	_, err := db.getClient().Database(db.database).Collection(coll).RemoveAll(context.Background(), M{"_id": id})
 
 	return err
 }
@@ -403,7 +435,7 @@ func (db *MongoDb) Remove(coll string, id interface{}) error {
 func (db *MongoDb) RemoveAll(coll string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	_, err := db.client.Database(db.database).Collection(coll).RemoveAll(ctx, M{})
+	_, err := db.getClient().Database(db.database).Collection(coll).RemoveAll(ctx, M{})
 
 	return err
 }
@@ -414,7 +446,7 @@ func (db *MongoDb) RemoveWithQuery(coll string, query interface{}) error {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	_, err := db.client.Database(db.database).Collection(coll).RemoveAll(ctx, query)
+	_, err := db.getClient().Database(db.database).Collection(coll).RemoveAll(ctx, query)
 
 	return err
 }
@@ -425,7 +457,7 @@ func (db *MongoDb) RemoveWithIDs(coll string, ids interface{}) error {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), db.maxTimeMS)
 	defer cancel()
-	_, err := db.client.Database(db.database).Collection(coll).RemoveAll(ctx, M{"_id": M{"$in": ids}})
+	_, err := db.getClient().Database(db.database).Collection(coll).RemoveAll(ctx, M{"_id": M{"$in": ids}})
 
 	return err
 }
@@ -469,7 +501,7 @@ func (db *MongoDb) RemoveWithIDs(coll string, ids interface{}) error {
 // 	var sess = db.sess.Copy()
 // 	defer sess.Close()
 
-// 	iter := db.client.Database(db.database).Collection(coll).Pipe(query).Iter()
+// 	iter := db.getClient().Database(db.database).Collection(coll).Pipe(query).Iter()
 // 	return f(iter)
 // }
 
@@ -8,8 +8,12 @@ import (
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 var (
@@ -17,15 +21,28 @@ var (
 	ErrNotFound         = errors.New("document is not found")
 	ErrInterfaceSlice   = errors.New("interface is not slice")
 	ErrInterfaceIsNil   = errors.New("interface is nil")
+	ErrGridFSFileID     = errors.New("gridfs: unexpected file id type")
 )
 
+// BSONOptions - тонкие настройки (де)кодирования BSON клиента
+type BSONOptions = options.BSONOptions
+
 // Mongo - обёртка над клиентом MongoDB
 type Mongo struct {
-	client   *mongo.Client
-	dbName   string
-	readPref *readpref.ReadPref
+	client       *mongo.Client
+	dbName       string
+	readPref     *readpref.ReadPref
+	readConcern  *readconcern.ReadConcern
+	writeConcern *writeconcern.WriteConcern
 }
 
+// M - сокращение для bson.M, используется как тип фильтров/документов
+// в обёртках над qmgo и нативным драйвером
+type M = bson.M
+
+// Pipeline - этапы агрегации, каждый этап - один документ вида {"$stage": ...}
+type Pipeline = []bson.M
+
 type Operation func(ctx context.Context) error
 type DecodeDocFunc func(m bson.M) error
 type CursorIterFunc func(cursor *mongo.Cursor) error
@@ -40,12 +57,18 @@ func NewMongo(ctx context.Context, opts *MongoOptions) (*Mongo, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Mongo{client: cli, dbName: opts.dbName, readPref: opts.readPref}, nil
+	return &Mongo{
+		client:       cli,
+		dbName:       opts.dbName,
+		readPref:     opts.readPref,
+		readConcern:  opts.readConcern,
+		writeConcern: opts.writeConcern,
+	}, nil
 }
 
 // InsertOne - вставка документа
 func (m Mongo) InsertOne(ctx context.Context, collection string, doc interface{}) error {
-	if !m.isConnect(ctx) {
+	if !m.checkConnection(ctx) {
 		return ErrClientDisconnect
 	}
 	coll := m.getCollection(collection)
@@ -58,7 +81,7 @@ func (m Mongo) InsertOne(ctx context.Context, collection string, doc interface{}
 
 // InsertMany - вставка документов
 func (m Mongo) InsertMany(ctx context.Context, collection string, value interface{}) error {
-	if !m.isConnect(ctx) {
+	if !m.checkConnection(ctx) {
 		return ErrClientDisconnect
 	}
 
@@ -77,7 +100,7 @@ func (m Mongo) InsertMany(ctx context.Context, collection string, value interfac
 
 // FindOne - поиск документа с декодирование в переменную `value`
 func (m Mongo) FindOne(ctx context.Context, collection string, filter interface{}, decoded DecodeDocFunc) (err error) {
-	if !m.isConnect(ctx) {
+	if !m.checkConnection(ctx) {
 		return ErrClientDisconnect
 	}
 	var res bson.M
@@ -92,7 +115,7 @@ func (m Mongo) FindOne(ctx context.Context, collection string, filter interface{
 
 // Find - поиск документов с возвратом курсора для его обхода
 func (m Mongo) Find(ctx context.Context, collection string, filter interface{}, iterFunc CursorIterFunc) error {
-	if !m.isConnect(ctx) {
+	if !m.checkConnection(ctx) {
 		return ErrClientDisconnect
 	}
 	cursor, err := m.getCollection(collection).Find(ctx, filter)
@@ -102,9 +125,32 @@ func (m Mongo) Find(ctx context.Context, collection string, filter interface{},
 	return errors.WithStack(iterFunc(cursor))
 }
 
+// UpdateOne - обновление первого документа, подходящего под filter
+func (m Mongo) UpdateOne(ctx context.Context, collection string, filter, update interface{}, upsert bool) error {
+	if !m.checkConnection(ctx) {
+		return ErrClientDisconnect
+	}
+	_, err := m.getCollection(collection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(upsert))
+	return errors.WithStack(err)
+}
+
+// UpdateMany - обновление всех документов, подходящих под filter
+func (m Mongo) UpdateMany(ctx context.Context, collection string, filter, update interface{}, upsert bool) error {
+	if !m.checkConnection(ctx) {
+		return ErrClientDisconnect
+	}
+	_, err := m.getCollection(collection).UpdateMany(ctx, filter, update, options.Update().SetUpsert(upsert))
+	return errors.WithStack(err)
+}
+
+// UpdateByID - обновление документа по _id
+func (m Mongo) UpdateByID(ctx context.Context, collection string, id, update interface{}, upsert bool) error {
+	return m.UpdateOne(ctx, collection, bson.M{"_id": id}, update, upsert)
+}
+
 // DeleteOne - удаление документа по заданному фильтру
 func (m Mongo) DeleteOne(ctx context.Context, collection string, filter interface{}) error {
-	if !m.isConnect(ctx) {
+	if !m.checkConnection(ctx) {
 		return ErrClientDisconnect
 	}
 	_, err := m.getCollection(collection).DeleteOne(ctx, filter)
@@ -116,7 +162,7 @@ func (m Mongo) DeleteOne(ctx context.Context, collection string, filter interfac
 
 // Aggregate - аггрегация данных в кастомной функцией итерации
 func (m Mongo) Aggregate(ctx context.Context, collection string, pipeline Pipeline, iterFunc CursorIterFunc) error {
-	if !m.isConnect(ctx) {
+	if !m.checkConnection(ctx) {
 		return ErrClientDisconnect
 	}
 	cursor, err := m.getCollection(collection).Aggregate(ctx, pipeline)
@@ -128,7 +174,7 @@ func (m Mongo) Aggregate(ctx context.Context, collection string, pipeline Pipeli
 }
 
 func (m Mongo) AggregateAll(ctx context.Context, collection string, pipeline Pipeline, result interface{}) error {
-	if !m.isConnect(ctx) {
+	if !m.checkConnection(ctx) {
 		return ErrClientDisconnect
 	}
 
@@ -157,6 +203,22 @@ func (m Mongo) isConnect(ctx context.Context) bool {
 	return m.client.Ping(ctx, nil) == nil
 }
 
+// checkConnection - проверка соединения перед выполнением команды.
+// Если в ctx уже есть активная сессия/транзакция (передана из WithTransaction
+// или WithSession), повторный Ping пропускается - он только добавил бы лишнюю
+// команду внутрь уже открытой транзакции
+func (m Mongo) checkConnection(ctx context.Context) bool {
+	return hasSession(ctx) || m.isConnect(ctx)
+}
+
+// hasSession - определяет, несёт ли ctx активную сессию/транзакцию драйвера.
+// Используется mongo.SessionFromContext вместо прямого приведения типа,
+// т.к. ctx, обёрнутый через context.WithTimeout/WithValue поверх sessCtx,
+// больше не реализует mongo.SessionContext напрямую, но всё ещё несёт сессию
+func hasSession(ctx context.Context) bool {
+	return mongo.SessionFromContext(ctx) != nil
+}
+
 func (m Mongo) getCollection(collection string) *mongo.Collection {
 	return m.client.Database(m.dbName).Collection(collection)
 }
@@ -220,6 +282,24 @@ func SetDBName(name string) MongoOption {
 	})
 }
 
+// SetReadConcern - установка read concern по-умолчанию для клиента
+func SetReadConcern(rc *readconcern.ReadConcern) MongoOption {
+	return Options(func(options MongoOptions) MongoOptions {
+		options.options.SetReadConcern(rc)
+		options.readConcern = rc
+		return options
+	})
+}
+
+// SetWriteConcern - установка write concern по-умолчанию для клиента
+func SetWriteConcern(wc *writeconcern.WriteConcern) MongoOption {
+	return Options(func(options MongoOptions) MongoOptions {
+		options.options.SetWriteConcern(wc)
+		options.writeConcern = wc
+		return options
+	})
+}
+
 // SetPreferred - установка предпочтения операции чтения
 // по-умолчанию выставляется режим secondaryPreferred
 // В большинстве случаев операции читаются из вторичных элементов,
@@ -236,3 +316,59 @@ func SetPreferred(mode readpref.Mode) MongoOption {
 		return options
 	})
 }
+
+// SetRegistry - установка пользовательского реестра BSON кодеков,
+// например для decimal.Decimal, кастомных ID-типов и т.п.
+func SetRegistry(registry *bsoncodec.Registry) MongoOption {
+	return Options(func(options MongoOptions) MongoOptions {
+		options.options.SetRegistry(registry)
+		return options
+	})
+}
+
+// SetBSONOptions - установка тонких настроек (де)кодирования BSON целиком
+func SetBSONOptions(bsonOpts *BSONOptions) MongoOption {
+	return Options(func(options MongoOptions) MongoOptions {
+		options.options.SetBSONOptions(bsonOpts)
+		return options
+	})
+}
+
+func (o MongoOptions) bsonOptionsOrNew() *BSONOptions {
+	if o.options.BSONOptions == nil {
+		return &BSONOptions{}
+	}
+	return o.options.BSONOptions
+}
+
+// SetNilSliceAsEmpty - кодирование nil-слайсов как пустого массива вместо null
+func SetNilSliceAsEmpty(v bool) MongoOption {
+	return Options(func(options MongoOptions) MongoOptions {
+		bsonOpts := options.bsonOptionsOrNew()
+		bsonOpts.NilSliceAsEmpty = v
+		options.options.SetBSONOptions(bsonOpts)
+		return options
+	})
+}
+
+// SetOmitZeroStruct - пропуск нулевых struct-значений при кодировании
+// полей, помеченных тегом `omitempty`; поля без этого тега кодируются как обычно
+func SetOmitZeroStruct(v bool) MongoOption {
+	return Options(func(options MongoOptions) MongoOptions {
+		bsonOpts := options.bsonOptionsOrNew()
+		bsonOpts.OmitZeroStruct = v
+		options.options.SetBSONOptions(bsonOpts)
+		return options
+	})
+}
+
+// SetUseJSONStructTags - использование тегов `json` вместо `bson` там,
+// где тег `bson` не указан явно
+func SetUseJSONStructTags(v bool) MongoOption {
+	return Options(func(options MongoOptions) MongoOptions {
+		bsonOpts := options.bsonOptionsOrNew()
+		bsonOpts.UseJSONStructTags = v
+		options.options.SetBSONOptions(bsonOpts)
+		return options
+	})
+}
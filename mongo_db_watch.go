@@ -0,0 +1,111 @@
+package libmongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	qmgoOptions "github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeEvent - декодированное событие change stream
+type ChangeEvent struct {
+	OperationType     string              `bson:"operationType"`
+	FullDocument      bson.Raw            `bson:"fullDocument"`
+	DocumentKey       bson.Raw            `bson:"documentKey"`
+	UpdateDescription bson.Raw            `bson:"updateDescription"`
+	ClusterTime       primitive.Timestamp `bson:"clusterTime"`
+	ResumeToken       bson.Raw            `bson:"_id"`
+}
+
+// ChangeHandler - обработчик события change stream
+type ChangeHandler func(ChangeEvent) error
+
+// WatchOptions - параметры подписки на change stream
+type WatchOptions struct {
+	Pipeline             []M
+	ResumeToken          bson.Raw
+	StartAtOperationTime *primitive.Timestamp
+	FullDocument         options.FullDocument
+}
+
+// Watch - подписка на события коллекции с автоматическим переподключением
+// по сохранённому resume token при обрывах соединения
+func (db *MongoDb) Watch(ctx context.Context, coll string, opts WatchOptions, handler ChangeHandler) error {
+	if !db.IsConnected() {
+		return fmt.Errorf("%s", errorNotConnected)
+	}
+
+	pipeline := make([]interface{}, 0, len(opts.Pipeline))
+	for _, p := range opts.Pipeline {
+		pipeline = append(pipeline, p)
+	}
+
+	resumeToken := opts.ResumeToken
+	startAt := opts.StartAtOperationTime
+
+	for {
+		streamOpts := options.ChangeStream()
+		if opts.FullDocument != "" {
+			streamOpts.SetFullDocument(opts.FullDocument)
+		}
+		if resumeToken != nil {
+			streamOpts.SetResumeAfter(resumeToken)
+		} else if startAt != nil {
+			streamOpts.SetStartAtOperationTime(startAt)
+		}
+
+		stream, err := db.getClient().Database(db.database).Collection(coll).Watch(ctx, pipeline, &qmgoOptions.ChangeStreamOptions{ChangeStreamOptions: streamOpts})
+		if err != nil {
+			if isTransientWatchErr(err) {
+				time.Sleep(watchReconnectDelay)
+				continue
+			}
+			return err
+		}
+
+		runErr := runChangeStream(ctx, stream, handler, &resumeToken)
+		if runErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return runErr
+		}
+		if !isTransientWatchErr(runErr) {
+			return runErr
+		}
+		time.Sleep(watchReconnectDelay)
+	}
+}
+
+func runChangeStream(ctx context.Context, stream *mongo.ChangeStream, handler ChangeHandler, resumeToken *bson.Raw) error {
+	defer stream.Close(ctx)
+
+	for stream.Next(ctx) {
+		var evt ChangeEvent
+		if err := stream.Decode(&evt); err != nil {
+			return err
+		}
+		*resumeToken = stream.ResumeToken()
+		if err := handler(evt); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
+
+func isTransientWatchErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if cmdErr, ok := err.(mongo.CommandError); ok {
+		return cmdErr.HasErrorLabel("ResumableChangeStreamError")
+	}
+	return mongo.IsNetworkError(err)
+}
+
+const watchReconnectDelay = 500 * time.Millisecond
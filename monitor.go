@@ -0,0 +1,164 @@
+package libmongo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	commandDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "libmongo_command_duration_seconds",
+			Help: "Duration of MongoDB commands executed through libmongo",
+		},
+		[]string{"command", "status"},
+	)
+	commandErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "libmongo_command_errors_total",
+			Help: "Number of failed MongoDB commands by command name",
+		},
+		[]string{"command"},
+	)
+	poolConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "libmongo_pool_connections",
+			Help: "Current connection pool size by state (in_use/available)",
+		},
+		[]string{"state"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(commandDuration, commandErrors, poolConnections)
+}
+
+// MonitorOptions - параметры наблюдателя за командами и пулом соединений
+type MonitorOptions struct {
+	TracerName string
+}
+
+type commandSpan struct {
+	span  trace.Span
+	start time.Time
+}
+
+// commandSpanTTL - максимальное время жизни незавершённого span'а в spans;
+// команды, для которых драйвер не прислал Succeeded/Failed (например, при
+// обрыве соединения), не должны копиться в карте бесконечно
+const commandSpanTTL = 5 * time.Minute
+
+// collectionFromCommand - извлечение имени коллекции из тела команды:
+// в команде MongoDB имя коллекции лежит значением под ключом, совпадающим
+// с именем самой команды (например {insert: "users", ...})
+func collectionFromCommand(evt *event.CommandStartedEvent) (string, bool) {
+	val, err := evt.Command.LookupErr(evt.CommandName)
+	if err != nil {
+		return "", false
+	}
+	return val.StringValueOK()
+}
+
+// evictStaleSpans - удаление из spans записей старше ttl вместе с
+// завершением их span'ов, чтобы оборванные без Succeeded/Failed команды
+// не утекали
+func evictStaleSpans(spans map[int64]*commandSpan, ttl time.Duration) {
+	cutoff := time.Now().Add(-ttl)
+	for id, cs := range spans {
+		if cs.start.Before(cutoff) {
+			cs.span.End()
+			delete(spans, id)
+		}
+	}
+}
+
+// SetMonitor - установка CommandMonitor/PoolMonitor, оборачивающего каждую
+// команду драйвера в span OpenTelemetry и метрики Prometheus, так что вызовы
+// Mongo не нуждаются в ручной инструментации
+func SetMonitor(opts MonitorOptions) MongoOption {
+	tracerName := opts.TracerName
+	if tracerName == "" {
+		tracerName = "libmongo"
+	}
+	tracer := otel.Tracer(tracerName)
+
+	var mu sync.Mutex
+	spans := make(map[int64]*commandSpan)
+
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.operation", evt.CommandName),
+			}
+			if coll, ok := collectionFromCommand(evt); ok {
+				attrs = append(attrs, attribute.String("db.mongodb.collection", coll))
+			}
+
+			_, span := tracer.Start(ctx, evt.CommandName, trace.WithAttributes(attrs...))
+
+			mu.Lock()
+			evictStaleSpans(spans, commandSpanTTL)
+			spans[evt.RequestID] = &commandSpan{span: span, start: time.Now()}
+			mu.Unlock()
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			mu.Lock()
+			cs, ok := spans[evt.RequestID]
+			delete(spans, evt.RequestID)
+			mu.Unlock()
+			if !ok {
+				return
+			}
+
+			cs.span.End()
+			commandDuration.WithLabelValues(evt.CommandName, "success").Observe(time.Since(cs.start).Seconds())
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			mu.Lock()
+			cs, ok := spans[evt.RequestID]
+			delete(spans, evt.RequestID)
+			mu.Unlock()
+			if !ok {
+				return
+			}
+
+			cs.span.SetStatus(codes.Error, evt.Failure)
+			cs.span.End()
+			commandDuration.WithLabelValues(evt.CommandName, "error").Observe(time.Since(cs.start).Seconds())
+			commandErrors.WithLabelValues(evt.CommandName).Inc()
+		},
+	}
+
+	poolMonitor := &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.GetSucceeded:
+				poolConnections.WithLabelValues("in_use").Inc()
+				poolConnections.WithLabelValues("available").Dec()
+			case event.ConnectionReturned:
+				poolConnections.WithLabelValues("in_use").Dec()
+				poolConnections.WithLabelValues("available").Inc()
+			case event.ConnectionCreated:
+				poolConnections.WithLabelValues("available").Inc()
+			case event.ConnectionClosed:
+				poolConnections.WithLabelValues("available").Dec()
+			}
+		},
+	}
+
+	return Options(func(options MongoOptions) MongoOptions {
+		options.options.SetMonitor(monitor)
+		options.options.SetPoolMonitor(poolMonitor)
+		return options
+	})
+}
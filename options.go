@@ -2,7 +2,9 @@ package libmongo
 
 import (
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 // MongoOption - абстракция сущности установки опций
@@ -31,9 +33,11 @@ func newOptions() MongoOptions {
 
 // MongoOptions - обёртка над опциями подключения
 type MongoOptions struct {
-	options  *options.ClientOptions
-	dbName   string
-	readPref *readpref.ReadPref
+	options      *options.ClientOptions
+	dbName       string
+	readPref     *readpref.ReadPref
+	readConcern  *readconcern.ReadConcern
+	writeConcern *writeconcern.WriteConcern
 }
 
 // ClientOptions - возвраз опций для подключен для клиента
@@ -45,6 +49,14 @@ func (m MongoOptions) DBName() string {
 	return m.dbName
 }
 
+func (m MongoOptions) ReadConcern() *readconcern.ReadConcern {
+	return m.readConcern
+}
+
+func (m MongoOptions) WriteConcern() *writeconcern.WriteConcern {
+	return m.writeConcern
+}
+
 // Options - тип функции применяющий опции к подключению
 type Options func(MongoOptions) MongoOptions
 
@@ -0,0 +1,194 @@
+package libmongo
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PageToken - декодированное содержимое opaque токена страницы:
+// значение поля сортировки и _id документа-якоря, а также направление
+// обхода относительно него
+type PageToken struct {
+	LastSortValue interface{} `bson:"last_sort_value"`
+	LastID        interface{} `bson:"last_id"`
+	Reverse       bool        `bson:"reverse,omitempty"`
+}
+
+// PaginateOpts - параметры постраничной выборки: либо offset/limit,
+// либо keyset-пагинация через PageToken
+type PaginateOpts struct {
+	Filter     interface{}
+	Projection interface{}
+	Sort       string // имя поля, "-поле" для убывающей сортировки
+	Limit      int64
+	Offset     int64
+	PageToken  string
+	WithTotal  bool
+}
+
+// PageInfo - результат постраничной выборки
+type PageInfo struct {
+	Total         int64
+	NextPageToken string
+	PrevPageToken string
+}
+
+// Paginate - постраничная выборка документов. Если задан PageToken,
+// используется keyset-пагинация (без Skip), что не деградирует на больших
+// коллекциях и устойчиво к параллельным вставкам
+func (db *MongoDb) Paginate(ctx context.Context, coll string, opts PaginateOpts, v interface{}) (PageInfo, error) {
+	if !db.IsConnected() {
+		return PageInfo{}, fmt.Errorf("%s", errorNotConnected)
+	}
+
+	var info PageInfo
+
+	filter := opts.Filter
+	if filter == nil {
+		filter = M{}
+	}
+
+	if opts.WithTotal {
+		total, err := db.getClient().Database(db.database).Collection(coll).Find(ctx, filter).Count()
+		if err != nil {
+			return info, err
+		}
+		info.Total = total
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	sortField := strings.TrimPrefix(opts.Sort, "-")
+	desc := strings.HasPrefix(opts.Sort, "-")
+
+	if opts.PageToken != "" && sortField == "" {
+		return info, fmt.Errorf("pagination: PageToken requires Sort to be set")
+	}
+
+	var reverse bool
+	// queryDesc - фактическое направление сортировки запроса: при обходе
+	// назад (reverse) оно инвертируется относительно запрошенного opts.Sort,
+	// а результат переворачивается обратно перед возвратом вызывающему
+	queryDesc := desc
+
+	if opts.PageToken != "" {
+		token, err := decodePageToken(opts.PageToken)
+		if err != nil {
+			return info, err
+		}
+		reverse = token.Reverse
+		if reverse {
+			queryDesc = !desc
+		}
+
+		cmp := "$gt"
+		if queryDesc {
+			cmp = "$lt"
+		}
+
+		keyset := M{
+			"$or": []M{
+				{sortField: M{cmp: token.LastSortValue}},
+				{sortField: token.LastSortValue, "_id": M{cmp: token.LastID}},
+			},
+		}
+
+		filter = M{"$and": []interface{}{filter, keyset}}
+	}
+
+	querySort := opts.Sort
+	if reverse {
+		if queryDesc {
+			querySort = "-" + sortField
+		} else {
+			querySort = sortField
+		}
+	}
+
+	query := db.getClient().Database(db.database).Collection(coll).Find(ctx, filter).Sort(querySort).Limit(limit)
+	if opts.Projection != nil {
+		query = query.Select(opts.Projection)
+	}
+	if opts.PageToken == "" && opts.Offset > 0 {
+		query = query.Skip(opts.Offset)
+	}
+
+	if err := query.All(v); err != nil {
+		return info, err
+	}
+
+	results := reflect.ValueOf(v).Elem()
+	if results.Len() == 0 {
+		return info, nil
+	}
+
+	if reverse {
+		for i, j := 0, results.Len()-1; i < j; i, j = i+1, j-1 {
+			vi, vj := results.Index(i).Interface(), results.Index(j).Interface()
+			results.Index(i).Set(reflect.ValueOf(vj))
+			results.Index(j).Set(reflect.ValueOf(vi))
+		}
+	}
+
+	// на странице, полученной обходом назад, дальше всегда есть данные -
+	// именно оттуда, где был взят PrevPageToken, породивший этот запрос
+	hasNext := reverse || int64(results.Len()) == limit
+
+	// PrevPageToken отдаётся только если текущая страница не первая - на
+	// первой странице идти назад некуда: она запрашивается без токена, а
+	// неполная страница обхода назад упирается в начало выборки
+	if opts.PageToken != "" && (!reverse || int64(results.Len()) == limit) {
+		if token, err := pageTokenFor(results.Index(0).Interface(), sortField, true); err == nil {
+			info.PrevPageToken = token
+		}
+	}
+	// NextPageToken отдаётся только когда дальше есть ещё данные - на
+	// последней, частичной странице обхода вперёд дальше элементов уже нет
+	if hasNext {
+		if token, err := pageTokenFor(results.Index(results.Len()-1).Interface(), sortField, false); err == nil {
+			info.NextPageToken = token
+		}
+	}
+
+	return info, nil
+}
+
+func pageTokenFor(doc interface{}, sortField string, reverse bool) (string, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+
+	var m bson.M
+	if err := bson.Unmarshal(raw, &m); err != nil {
+		return "", err
+	}
+
+	return encodePageToken(PageToken{LastSortValue: m[sortField], LastID: m["_id"], Reverse: reverse})
+}
+
+func encodePageToken(token PageToken) (string, error) {
+	b, err := bson.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+func decodePageToken(token string) (PageToken, error) {
+	var pt PageToken
+	raw, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return pt, err
+	}
+	err = bson.Unmarshal(raw, &pt)
+	return pt, err
+}
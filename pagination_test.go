@@ -0,0 +1,21 @@
+package libmongo
+
+import (
+	"testing"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	token, err := encodePageToken(PageToken{LastSortValue: "abc", LastID: "42"})
+	if err != nil {
+		t.Fatalf("encodePageToken failed: %v", err)
+	}
+
+	decoded, err := decodePageToken(token)
+	if err != nil {
+		t.Fatalf("decodePageToken failed: %v", err)
+	}
+
+	if decoded.LastSortValue != "abc" || decoded.LastID != "42" {
+		t.Fatalf("unexpected decoded token: %+v", decoded)
+	}
+}
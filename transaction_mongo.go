@@ -0,0 +1,58 @@
+package libmongo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WithTransaction - выполнение операции внутри ACID транзакции,
+// ретрай на TransientTransactionError/UnknownTransactionCommitResult
+// выполняется средствами самого драйвера
+func (m Mongo) WithTransaction(ctx context.Context, op Operation) error {
+	if !m.isConnect(ctx) {
+		return ErrClientDisconnect
+	}
+
+	sessOpts := options.Session()
+	if m.readPref != nil {
+		sessOpts.SetDefaultReadPreference(m.readPref)
+	}
+	if m.readConcern != nil {
+		sessOpts.SetDefaultReadConcern(m.readConcern)
+	}
+	if m.writeConcern != nil {
+		sessOpts.SetDefaultWriteConcern(m.writeConcern)
+	}
+
+	sess, err := m.client.StartSession(sessOpts)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer sess.EndSession(ctx)
+
+	_, err = sess.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, op(sessCtx)
+	})
+	return errors.WithStack(err)
+}
+
+// WithSession - выполнение операции в рамках causally-consistent сессии
+// без открытия транзакции
+func (m Mongo) WithSession(ctx context.Context, op Operation) error {
+	if !m.isConnect(ctx) {
+		return ErrClientDisconnect
+	}
+
+	sess, err := m.client.StartSession()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer sess.EndSession(ctx)
+
+	return errors.WithStack(mongo.WithSession(ctx, sess, func(sessCtx mongo.SessionContext) error {
+		return op(sessCtx)
+	}))
+}
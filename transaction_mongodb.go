@@ -0,0 +1,32 @@
+package libmongo
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithTransaction - выполнение операции внутри ACID транзакции,
+// ретрай на ошибках с лейблом TransientTransactionError/UnknownTransactionCommitResult
+// выполняется средствами драйвера через qmgo.Client.DoTransaction.
+// Методы MongoDb (Insert, Find, UpdateWithQuery и т.д.) сами открывают фоновый
+// context и не принимают его снаружи, поэтому внутри op для выполнения
+// операций в рамках транзакции нужно обращаться к db.getClient() с sessCtx
+// напрямую, а не через эти методы
+func (db *MongoDb) WithTransaction(ctx context.Context, op Operation) error {
+	if !db.IsConnected() {
+		return fmt.Errorf("%s", errorNotConnected)
+	}
+
+	_, err := db.getClient().DoTransaction(ctx, func(sessCtx context.Context) (interface{}, error) {
+		return nil, op(sessCtx)
+	})
+	return err
+}
+
+// WithSession - выполнение операции в рамках сессии. qmgo не отдаёт наружу
+// обёрнутую mongo.Session (qmgo.Session не экспортирует её), поэтому
+// запустить causally-consistent сессию без транзакции его публичным API
+// нельзя - используется та же транзакционная машинерия, что и WithTransaction
+func (db *MongoDb) WithSession(ctx context.Context, op Operation) error {
+	return db.WithTransaction(ctx, op)
+}